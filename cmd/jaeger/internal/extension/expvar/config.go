@@ -0,0 +1,80 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+)
+
+// defaultRecoveryDuration is the HealthConfig.RecoveryDuration applied when
+// the operator leaves it unset. A zero duration would promote every
+// RecoverableError to unhealthy instantly, defeating the point of
+// debouncing, so createDefaultConfig gives it this non-zero default.
+const defaultRecoveryDuration = 30 * time.Second
+
+// HealthConfig configures the component-status health endpoint served
+// alongside expvar.
+type HealthConfig struct {
+	// Path is the base path at which the health endpoint is served.
+	// Pipeline-scoped queries are served at "{Path}/{pipeline}".
+	Path string `mapstructure:"path"`
+
+	// RecoveryDuration is how long a component may sit in a
+	// RecoverableError state before the aggregator promotes it to
+	// unhealthy. This debounces transient errors so a single flaky
+	// call does not flip the whole endpoint to 503.
+	RecoveryDuration time.Duration `mapstructure:"recovery_duration"`
+}
+
+// PrometheusConfig configures the Prometheus exposition bridge served
+// alongside expvar.
+type PrometheusConfig struct {
+	// Enabled turns on the /metrics endpoint.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Path is the path at which Prometheus text exposition is served.
+	// Defaults to "/metrics".
+	Path string `mapstructure:"path"`
+
+	// Prefix is prepended to every metric name, e.g. "jaeger_".
+	Prefix string `mapstructure:"prefix"`
+
+	// LabelKeys names the label applied at each nesting depth of an
+	// expvar.Map, in order. For example, a Map named "spans_received"
+	// keyed by transport with LabelKeys ["transport"] and Prefix "jaeger_"
+	// is exported as jaeger_spans_received{transport="grpc"}. Nesting deeper than
+	// len(LabelKeys) instead folds the remaining keys into the metric
+	// name.
+	LabelKeys []string `mapstructure:"label_keys"`
+}
+
+// Config defines the configuration for the expvar extension.
+type Config struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// Health configures the /health endpoint. Leave Path empty to use
+	// the "/health" default.
+	Health HealthConfig `mapstructure:"health"`
+
+	// Prometheus configures the /metrics endpoint.
+	Prometheus PrometheusConfig `mapstructure:"prometheus"`
+
+	// GRPC optionally starts an ExpvarService gRPC listener alongside
+	// the HTTP server, letting remote tools pull runtime vars without
+	// HTTP scraping.
+	GRPC configoptional.Optional[configgrpc.ServerConfig] `mapstructure:"grpc"`
+
+	// Logging selects which backend(s) request and lifecycle events are
+	// recorded to.
+	Logging LoggingConfig `mapstructure:"logging"`
+}
+
+// Validate checks that the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// This file is hand-written, not generated by protoc/buf. The repo has no
+// protoc/buf toolchain wired up for this package yet, so the message types
+// described by expvar.proto are maintained by hand here, kept in sync with
+// the .proto by inspection. They satisfy the legacy (pre-APIv2) v1
+// proto.Message shape — Reset/String/ProtoMessage plus "protobuf" struct
+// tags — which google.golang.org/protobuf still supports via its legacy
+// message wrapper, so these round-trip correctly through grpc-go's default
+// codec (see expvar_roundtrip_test.go). If protoc/buf generation is added
+// for this package later, this file should be replaced with real generated
+// output and this notice deleted.
+
+package expvarpb
+
+import (
+	fmt "fmt"
+)
+
+type ListVarsRequest struct{}
+
+func (m *ListVarsRequest) Reset()      { *m = ListVarsRequest{} }
+func (*ListVarsRequest) ProtoMessage() {}
+func (m *ListVarsRequest) String() string {
+	return fmt.Sprintf("%+v", *m)
+}
+
+type ListVarsResponse struct {
+	Names []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+}
+
+func (m *ListVarsResponse) Reset()      { *m = ListVarsResponse{} }
+func (*ListVarsResponse) ProtoMessage() {}
+func (m *ListVarsResponse) String() string {
+	return fmt.Sprintf("%+v", *m)
+}
+
+func (m *ListVarsResponse) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+type GetVarRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *GetVarRequest) Reset()      { *m = GetVarRequest{} }
+func (*GetVarRequest) ProtoMessage() {}
+func (m *GetVarRequest) String() string {
+	return fmt.Sprintf("%+v", *m)
+}
+
+func (m *GetVarRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GetVarResponse struct {
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	JsonValue string `protobuf:"bytes,2,opt,name=json_value,json=jsonValue,proto3" json:"json_value,omitempty"`
+}
+
+func (m *GetVarResponse) Reset()      { *m = GetVarResponse{} }
+func (*GetVarResponse) ProtoMessage() {}
+func (m *GetVarResponse) String() string {
+	return fmt.Sprintf("%+v", *m)
+}
+
+func (m *GetVarResponse) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *GetVarResponse) GetJsonValue() string {
+	if m != nil {
+		return m.JsonValue
+	}
+	return ""
+}
+
+type StreamVarsRequest struct {
+	IntervalSeconds int64 `protobuf:"varint,1,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+}
+
+func (m *StreamVarsRequest) Reset()      { *m = StreamVarsRequest{} }
+func (*StreamVarsRequest) ProtoMessage() {}
+func (m *StreamVarsRequest) String() string {
+	return fmt.Sprintf("%+v", *m)
+}
+
+func (m *StreamVarsRequest) GetIntervalSeconds() int64 {
+	if m != nil {
+		return m.IntervalSeconds
+	}
+	return 0
+}
@@ -0,0 +1,181 @@
+// This file is hand-written in the shape of protoc-gen-go-grpc output, like
+// expvar.pb.go beside it; keep it in sync with expvar.proto by inspection.
+// source: expvar.proto
+
+package expvarpb
+
+import (
+	context "context"
+
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+const (
+	ExpvarService_ListVars_FullMethodName   = "/jaeger.expvar.ExpvarService/ListVars"
+	ExpvarService_GetVar_FullMethodName     = "/jaeger.expvar.ExpvarService/GetVar"
+	ExpvarService_StreamVars_FullMethodName = "/jaeger.expvar.ExpvarService/StreamVars"
+)
+
+// ExpvarServiceClient is the client API for ExpvarService.
+type ExpvarServiceClient interface {
+	ListVars(ctx context.Context, in *ListVarsRequest, opts ...grpc.CallOption) (*ListVarsResponse, error)
+	GetVar(ctx context.Context, in *GetVarRequest, opts ...grpc.CallOption) (*GetVarResponse, error)
+	StreamVars(ctx context.Context, in *StreamVarsRequest, opts ...grpc.CallOption) (ExpvarService_StreamVarsClient, error)
+}
+
+type expvarServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExpvarServiceClient(cc grpc.ClientConnInterface) ExpvarServiceClient {
+	return &expvarServiceClient{cc}
+}
+
+func (c *expvarServiceClient) ListVars(ctx context.Context, in *ListVarsRequest, opts ...grpc.CallOption) (*ListVarsResponse, error) {
+	out := new(ListVarsResponse)
+	if err := c.cc.Invoke(ctx, ExpvarService_ListVars_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expvarServiceClient) GetVar(ctx context.Context, in *GetVarRequest, opts ...grpc.CallOption) (*GetVarResponse, error) {
+	out := new(GetVarResponse)
+	if err := c.cc.Invoke(ctx, ExpvarService_GetVar_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *expvarServiceClient) StreamVars(ctx context.Context, in *StreamVarsRequest, opts ...grpc.CallOption) (ExpvarService_StreamVarsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ExpvarService_ServiceDesc.Streams[0], ExpvarService_StreamVars_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &expvarServiceStreamVarsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// ExpvarService_StreamVarsClient is the client-side stream handle for
+// ExpvarService.StreamVars.
+type ExpvarService_StreamVarsClient interface {
+	Recv() (*GetVarResponse, error)
+	grpc.ClientStream
+}
+
+type expvarServiceStreamVarsClient struct {
+	grpc.ClientStream
+}
+
+func (x *expvarServiceStreamVarsClient) Recv() (*GetVarResponse, error) {
+	m := new(GetVarResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExpvarServiceServer is the server API for ExpvarService.
+type ExpvarServiceServer interface {
+	ListVars(context.Context, *ListVarsRequest) (*ListVarsResponse, error)
+	GetVar(context.Context, *GetVarRequest) (*GetVarResponse, error)
+	StreamVars(*StreamVarsRequest, ExpvarService_StreamVarsServer) error
+}
+
+// UnimplementedExpvarServiceServer can be embedded to have forward
+// compatible implementations.
+type UnimplementedExpvarServiceServer struct{}
+
+func (UnimplementedExpvarServiceServer) ListVars(context.Context, *ListVarsRequest) (*ListVarsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListVars not implemented")
+}
+
+func (UnimplementedExpvarServiceServer) GetVar(context.Context, *GetVarRequest) (*GetVarResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetVar not implemented")
+}
+
+func (UnimplementedExpvarServiceServer) StreamVars(*StreamVarsRequest, ExpvarService_StreamVarsServer) error {
+	return status.Error(codes.Unimplemented, "method StreamVars not implemented")
+}
+
+// ExpvarService_StreamVarsServer is the server-side stream handle for
+// ExpvarService.StreamVars.
+type ExpvarService_StreamVarsServer interface {
+	Send(*GetVarResponse) error
+	grpc.ServerStream
+}
+
+type expvarServiceStreamVarsServer struct {
+	grpc.ServerStream
+}
+
+func (x *expvarServiceStreamVarsServer) Send(m *GetVarResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func RegisterExpvarServiceServer(s grpc.ServiceRegistrar, srv ExpvarServiceServer) {
+	s.RegisterService(&ExpvarService_ServiceDesc, srv)
+}
+
+func _ExpvarService_ListVars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpvarServiceServer).ListVars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ExpvarService_ListVars_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpvarServiceServer).ListVars(ctx, req.(*ListVarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpvarService_GetVar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExpvarServiceServer).GetVar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ExpvarService_GetVar_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExpvarServiceServer).GetVar(ctx, req.(*GetVarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExpvarService_StreamVars_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamVarsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExpvarServiceServer).StreamVars(m, &expvarServiceStreamVarsServer{stream})
+}
+
+var ExpvarService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jaeger.expvar.ExpvarService",
+	HandlerType: (*ExpvarServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ListVars", Handler: _ExpvarService_ListVars_Handler},
+		{MethodName: "GetVar", Handler: _ExpvarService_GetVar_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamVars",
+			Handler:       _ExpvarService_StreamVars_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "expvar.proto",
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package expvartest provides reusable in-process fakes for the common
+// OTel auth extensions (bearer-token, basic-auth, headers-setter) so
+// downstream Jaeger extensions can assert that configauth.Config.AuthenticatorID
+// actually gates access to their endpoints, without pulling in the real
+// authextension implementations as test dependencies.
+package expvartest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+)
+
+// AuthType identifies which fake authenticator to build.
+type AuthType string
+
+const (
+	BearerToken   AuthType = "bearer"
+	BasicAuth     AuthType = "basic"
+	HeadersSetter AuthType = "headers-setter"
+)
+
+// Credentials carries whichever fields are relevant to the chosen
+// AuthType; unused fields are ignored.
+type Credentials struct {
+	Token       string
+	Username    string
+	Password    string
+	Header      string
+	HeaderValue string
+}
+
+// NewAuthenticator builds an in-process extensionauth.Server that
+// validates requests the way the named real extension would.
+func NewAuthenticator(authType AuthType, creds Credentials) extensionauth.Server {
+	switch authType {
+	case BearerToken:
+		return staticAuthenticator(func(headers map[string][]string) (context.Context, error) {
+			if firstHeader(headers, "authorization") != "Bearer "+creds.Token {
+				return nil, errors.New("expvartest: invalid bearer token")
+			}
+			return authenticatedContext("bearer-user"), nil
+		})
+	case BasicAuth:
+		return staticAuthenticator(func(headers map[string][]string) (context.Context, error) {
+			req := &http.Request{Header: http.Header(headers)}
+			user, pass, ok := req.BasicAuth()
+			if !ok || user != creds.Username || pass != creds.Password {
+				return nil, errors.New("expvartest: invalid basic auth credentials")
+			}
+			return authenticatedContext(user), nil
+		})
+	case HeadersSetter:
+		return staticAuthenticator(func(headers map[string][]string) (context.Context, error) {
+			if firstHeader(headers, creds.Header) != creds.HeaderValue {
+				return nil, fmt.Errorf("expvartest: missing or invalid %q header", creds.Header)
+			}
+			return authenticatedContext("headers-setter-user"), nil
+		})
+	default:
+		panic(fmt.Sprintf("expvartest: unknown auth type %q", authType))
+	}
+}
+
+// NewHost returns a component.Host exposing a single fake authenticator
+// extension under id, suitable for passing to Start() on the component
+// under test.
+func NewHost(t *testing.T, id component.ID, authType AuthType, creds Credentials) component.Host {
+	t.Helper()
+	return &fakeHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			id: fakeAuthExtension{Server: NewAuthenticator(authType, creds)},
+		},
+	}
+}
+
+// NewAuthedClient returns an http.Client that presents valid credentials
+// for authType on every request, so callers can exercise the success
+// path against a server protected by the matching authenticator.
+func NewAuthedClient(t *testing.T, authType AuthType, creds Credentials) *http.Client {
+	t.Helper()
+	return &http.Client{Transport: credentialInjector{authType: authType, creds: creds}}
+}
+
+type credentialInjector struct {
+	authType AuthType
+	creds    Credentials
+}
+
+func (c credentialInjector) RoundTrip(req *http.Request) (*http.Response, error) {
+	cloned := req.Clone(req.Context())
+	switch c.authType {
+	case BearerToken:
+		cloned.Header.Set("Authorization", "Bearer "+c.creds.Token)
+	case BasicAuth:
+		cloned.SetBasicAuth(c.creds.Username, c.creds.Password)
+	case HeadersSetter:
+		cloned.Header.Set(c.creds.Header, c.creds.HeaderValue)
+	}
+	return http.DefaultTransport.RoundTrip(cloned)
+}
+
+type staticAuthenticator func(headers map[string][]string) (context.Context, error)
+
+func (f staticAuthenticator) Start(context.Context, component.Host) error { return nil }
+func (f staticAuthenticator) Shutdown(context.Context) error              { return nil }
+func (f staticAuthenticator) Authenticate(_ context.Context, headers map[string][]string) (context.Context, error) {
+	return f(headers)
+}
+
+type fakeAuthExtension struct {
+	extensionauth.Server
+}
+
+func (fakeAuthExtension) Start(context.Context, component.Host) error { return nil }
+func (fakeAuthExtension) Shutdown(context.Context) error              { return nil }
+
+type fakeHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *fakeHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func firstHeader(headers map[string][]string, key string) string {
+	for k, values := range headers {
+		if len(values) > 0 && httpCanonicalEqual(k, key) {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func httpCanonicalEqual(a, b string) bool {
+	return http.CanonicalHeaderKey(a) == http.CanonicalHeaderKey(b)
+}
+
+// authAttrs implements client.AuthData so authSubject() in the expvar
+// extension can read back the authenticated subject.
+type authAttrs map[string]any
+
+func (a authAttrs) GetAttribute(name string) any { return a[name] }
+
+func (a authAttrs) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for k := range a {
+		names = append(names, k)
+	}
+	return names
+}
+
+func authenticatedContext(subject string) context.Context {
+	return client.NewContext(context.Background(), client.Info{
+		Auth: authAttrs{"subject": subject},
+	})
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/jaegertracing/jaeger/cmd/jaeger/internal/extension/expvar/expvartest"
+)
+
+var authenticatorID = component.MustNewID("fake_auth")
+
+func newAuthedTestExtension(t *testing.T, endpoint string, authType expvartest.AuthType, creds expvartest.Credentials) *stateExtension {
+	t.Helper()
+	config := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: endpoint,
+			Auth: configoptional.Some(confighttp.AuthConfig{
+				Config: configauth.Config{AuthenticatorID: authenticatorID},
+			}),
+		},
+	}
+	s := newExtension(config, component.TelemetrySettings{Logger: zaptest.NewLogger(t)})
+	host := expvartest.NewHost(t, authenticatorID, authType, creds)
+	require.NoError(t, s.Start(context.Background(), host))
+	t.Cleanup(func() { s.Shutdown(context.Background()) })
+	return s
+}
+
+func getWithClient(t *testing.T, client *http.Client, endpoint string) *http.Response {
+	t.Helper()
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		r, err := client.Get(fmt.Sprintf("http://%s/", endpoint))
+		if err != nil {
+			return false
+		}
+		resp = r
+		return true
+	}, 5*time.Second, 100*time.Millisecond)
+	return resp
+}
+
+func TestExpvarExtension_BearerAuth(t *testing.T) {
+	creds := expvartest.Credentials{Token: "s3cr3t"}
+	newAuthedTestExtension(t, "0.0.0.0:27790", expvartest.BearerToken, creds)
+
+	authed := expvartest.NewAuthedClient(t, expvartest.BearerToken, creds)
+	resp := getWithClient(t, authed, "0.0.0.0:27790")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = getWithClient(t, http.DefaultClient, "0.0.0.0:27790")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestExpvarExtension_BasicAuth(t *testing.T) {
+	creds := expvartest.Credentials{Username: "alice", Password: "hunter2"}
+	newAuthedTestExtension(t, "0.0.0.0:27791", expvartest.BasicAuth, creds)
+
+	authed := expvartest.NewAuthedClient(t, expvartest.BasicAuth, creds)
+	resp := getWithClient(t, authed, "0.0.0.0:27791")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	wrongCreds := expvartest.NewAuthedClient(t, expvartest.BasicAuth, expvartest.Credentials{Username: "alice", Password: "wrong"})
+	resp = getWithClient(t, wrongCreds, "0.0.0.0:27791")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestExpvarExtension_HeadersSetterAuth(t *testing.T) {
+	creds := expvartest.Credentials{Header: "X-Api-Key", HeaderValue: "abc123"}
+	newAuthedTestExtension(t, "0.0.0.0:27792", expvartest.HeadersSetter, creds)
+
+	authed := expvartest.NewAuthedClient(t, expvartest.HeadersSetter, creds)
+	resp := getWithClient(t, authed, "0.0.0.0:27792")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = getWithClient(t, http.DefaultClient, "0.0.0.0:27792")
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
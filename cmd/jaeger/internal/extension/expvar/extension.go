@@ -0,0 +1,156 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// Port is the default port on which the expvar extension listens.
+const Port = 27777
+
+// stateExtension serves the process's expvar registry over HTTP, along
+// with an aggregated component-health view built from status events
+// reported by the rest of the collector.
+type stateExtension struct {
+	config     *Config
+	telemetry  component.TelemetrySettings
+	server     *http.Server
+	grpcServer *grpc.Server
+	aggregator *statusAggregator
+	logger     *requestLogger
+}
+
+func newExtension(config *Config, telemetry component.TelemetrySettings) *stateExtension {
+	return &stateExtension{
+		config:     config,
+		telemetry:  telemetry,
+		aggregator: newStatusAggregator(config.Health.RecoveryDuration),
+		logger:     newRequestLogger(config.Logging, telemetry.Logger),
+	}
+}
+
+func (s *stateExtension) Start(ctx context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.Handle("/", expvar.Handler())
+	mux.HandleFunc(s.healthPath(), s.handleHealth)
+	mux.HandleFunc(s.healthPath()+"/", s.handleHealth)
+	if s.config.Prometheus.Enabled {
+		mux.HandleFunc(s.prometheusPath(), s.handlePrometheus)
+	}
+
+	listener, err := s.config.ServerConfig.ToListener(ctx)
+	if err != nil {
+		return err
+	}
+	srv, err := s.config.ServerConfig.ToServer(ctx, host, s.telemetry, s.logger.middleware(mux))
+	if err != nil {
+		return err
+	}
+	s.server = srv
+
+	go func() {
+		if serveErr := s.server.Serve(listener); serveErr != nil && serveErr != http.ErrServerClosed {
+			s.telemetry.Logger.Error("expvar server failed", zap.Error(serveErr))
+		}
+	}()
+	s.logger.logLifecycle("start", "endpoint", s.config.ServerConfig.Endpoint)
+
+	if s.config.GRPC.HasValue() {
+		if err := s.startGRPC(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *stateExtension) startGRPC(ctx context.Context, host component.Host) error {
+	grpcCfg := s.config.GRPC.Get()
+
+	listener, err := grpcCfg.NetAddr.Listen(ctx)
+	if err != nil {
+		return err
+	}
+	srv, err := grpcCfg.ToServer(ctx, host, s.telemetry)
+	if err != nil {
+		return err
+	}
+	s.grpcServer = srv
+	registerExpvarService(srv)
+
+	go func() {
+		if serveErr := s.grpcServer.Serve(listener); serveErr != nil {
+			s.telemetry.Logger.Error("expvar gRPC server failed", zap.Error(serveErr))
+		}
+	}()
+	return nil
+}
+
+func (s *stateExtension) Shutdown(ctx context.Context) error {
+	if s.grpcServer != nil {
+		stopped := make(chan struct{})
+		go func() {
+			s.grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			// A graceful stop can block forever on a long-lived streaming
+			// RPC (e.g. StreamVars); once the caller's deadline is up, force
+			// the listener and any in-flight RPCs closed instead of hanging.
+			s.grpcServer.Stop()
+		}
+	}
+	if s.server == nil {
+		return nil
+	}
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.logger.logLifecycle("shutdown_timeout", "endpoint", s.config.ServerConfig.Endpoint, "error", err.Error())
+		return err
+	}
+	s.logger.logLifecycle("shutdown", "endpoint", s.config.ServerConfig.Endpoint)
+	return nil
+}
+
+// ComponentStatusChanged implements componentstatus.Watcher, letting the
+// collector service notify this extension of every status event so it
+// can be reflected on the /health endpoint.
+func (s *stateExtension) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	s.aggregator.RecordEvent(source, event)
+}
+
+func (s *stateExtension) healthPath() string {
+	if s.config.Health.Path == "" {
+		return "/health"
+	}
+	return s.config.Health.Path
+}
+
+func (s *stateExtension) prometheusPath() string {
+	if s.config.Prometheus.Path == "" {
+		return "/metrics"
+	}
+	return s.config.Prometheus.Path
+}
+
+func (s *stateExtension) handleHealth(w http.ResponseWriter, r *http.Request) {
+	pipeline := strings.TrimPrefix(r.URL.Path, s.healthPath())
+	pipeline = strings.Trim(pipeline, "/")
+	verbose := r.URL.Query().Get("verbose") == "true"
+
+	report, code := s.aggregator.Report(pipeline, verbose)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/extension"
+)
+
+// componentType is the type of the expvar extension, as used in the
+// collector configuration (e.g. `extensions: expvar:`).
+var componentType = component.MustNewType("expvar")
+
+// NewFactory creates a factory for the expvar extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		componentType,
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelBeta,
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: fmt.Sprintf(":%d", Port),
+		},
+		Health: HealthConfig{
+			Path:             "/health",
+			RecoveryDuration: defaultRecoveryDuration,
+		},
+		Prometheus: PrometheusConfig{
+			Path: "/metrics",
+		},
+	}
+}
+
+func createExtension(_ context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+	return newExtension(cfg.(*Config), set.TelemetrySettings), nil
+}
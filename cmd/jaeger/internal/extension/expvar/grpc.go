@@ -0,0 +1,77 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/jaegertracing/jaeger/cmd/jaeger/internal/extension/expvar/expvarpb"
+)
+
+const defaultStreamInterval = 10 * time.Second
+
+// expvarServer implements expvarpb.ExpvarServiceServer on top of the
+// same process-wide expvar registry the HTTP handlers walk.
+type expvarServer struct {
+	expvarpb.UnimplementedExpvarServiceServer
+}
+
+func (expvarServer) ListVars(context.Context, *expvarpb.ListVarsRequest) (*expvarpb.ListVarsResponse, error) {
+	var names []string
+	expvar.Do(func(kv expvar.KeyValue) {
+		names = append(names, kv.Key)
+	})
+	sort.Strings(names)
+	return &expvarpb.ListVarsResponse{Names: names}, nil
+}
+
+func (expvarServer) GetVar(_ context.Context, req *expvarpb.GetVarRequest) (*expvarpb.GetVarResponse, error) {
+	v := expvar.Get(req.GetName())
+	if v == nil {
+		return &expvarpb.GetVarResponse{Name: req.GetName()}, nil
+	}
+	return &expvarpb.GetVarResponse{Name: req.GetName(), JsonValue: v.String()}, nil
+}
+
+func (s expvarServer) StreamVars(req *expvarpb.StreamVarsRequest, stream expvarpb.ExpvarService_StreamVarsServer) error {
+	interval := time.Duration(req.GetIntervalSeconds()) * time.Second
+	if interval <= 0 {
+		interval = defaultStreamInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := stream.Send(snapshotVars()); err != nil {
+			return err
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// snapshotVars encodes the full expvar registry as a single JSON object,
+// mirroring what the built-in expvar.Handler serves over HTTP.
+func snapshotVars() *expvarpb.GetVarResponse {
+	snapshot := make(map[string]json.RawMessage)
+	expvar.Do(func(kv expvar.KeyValue) {
+		snapshot[kv.Key] = json.RawMessage(kv.Value.String())
+	})
+	encoded, _ := json.Marshal(snapshot)
+	return &expvarpb.GetVarResponse{JsonValue: string(encoded)}
+}
+
+func registerExpvarService(srv *grpc.Server) {
+	expvarpb.RegisterExpvarServiceServer(srv, expvarServer{})
+}
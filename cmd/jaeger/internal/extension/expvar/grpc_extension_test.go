@@ -0,0 +1,43 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExpvarExtension_StartErrorGRPCAuth(t *testing.T) {
+	config := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: "0.0.0.0:27784",
+		},
+		GRPC: configoptional.Some(configgrpc.ServerConfig{
+			NetAddr: confignet.AddrConfig{
+				Endpoint:  "0.0.0.0:27785",
+				Transport: confignet.TransportTypeTCP,
+			},
+			Auth: configoptional.Some(configgrpc.AuthConfig{
+				Config: configauth.Config{
+					AuthenticatorID: component.MustNewID("invalid_auth"),
+				},
+			}),
+		}),
+	}
+	s := newExtension(config, component.TelemetrySettings{
+		Logger: zaptest.NewLogger(t),
+	})
+	err := s.Start(context.Background(), storagetest.NewStorageHost())
+	require.ErrorContains(t, err, "invalid_auth")
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/jaegertracing/jaeger/cmd/jaeger/internal/extension/expvar/expvarpb"
+)
+
+func TestExpvarServer_ListVarsAndGetVar(t *testing.T) {
+	srv := expvarServer{}
+
+	list, err := srv.ListVars(context.Background(), &expvarpb.ListVarsRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, list)
+
+	resp, err := srv.GetVar(context.Background(), &expvarpb.GetVarRequest{Name: "does-not-exist"})
+	require.NoError(t, err)
+	require.Equal(t, "does-not-exist", resp.GetName())
+	require.Empty(t, resp.GetJsonValue())
+}
+
+type recordingStreamVarsServer struct {
+	expvarpb.ExpvarService_StreamVarsServer
+	ctx  context.Context
+	sent chan *expvarpb.GetVarResponse
+}
+
+func (r *recordingStreamVarsServer) Context() context.Context { return r.ctx }
+
+func (r *recordingStreamVarsServer) Send(resp *expvarpb.GetVarResponse) error {
+	r.sent <- resp
+	return nil
+}
+
+func TestExpvarServer_StreamVarsStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &recordingStreamVarsServer{ctx: ctx, sent: make(chan *expvarpb.GetVarResponse, 1)}
+
+	done := make(chan error, 1)
+	go func() {
+		srv := expvarServer{}
+		done <- srv.StreamVars(&expvarpb.StreamVarsRequest{IntervalSeconds: 1}, stream)
+	}()
+
+	<-stream.sent // first snapshot is sent immediately
+	cancel()
+
+	err := <-done
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExpvarExtension_HealthEndpoint(t *testing.T) {
+	config := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: "0.0.0.0:27781",
+		},
+	}
+	s := newExtension(config, component.TelemetrySettings{
+		Logger: zaptest.NewLogger(t),
+	})
+	require.NoError(t, s.Start(context.Background(), storagetest.NewStorageHost()))
+	defer s.Shutdown(context.Background())
+
+	addr := fmt.Sprintf("http://0.0.0.0:%d/health", 27781)
+	client := &http.Client{}
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		r, err := client.Get(addr)
+		if err != nil {
+			return false
+		}
+		resp = r
+		return true
+	}, 5*time.Second, 100*time.Millisecond)
+	defer resp.Body.Close()
+
+	// No components have reported yet, so the aggregate report is empty
+	// and defaults to OK.
+	var report healthReport
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	require.Equal(t, "StatusOK", report.Status)
+}
+
+func TestExpvarExtension_HealthEndpointVerbose(t *testing.T) {
+	config := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: "0.0.0.0:27782",
+		},
+	}
+	s := newExtension(config, component.TelemetrySettings{
+		Logger: zaptest.NewLogger(t),
+	})
+	require.NoError(t, s.Start(context.Background(), storagetest.NewStorageHost()))
+	defer s.Shutdown(context.Background())
+
+	addr := fmt.Sprintf("http://0.0.0.0:%d/health?verbose=true", 27782)
+	client := &http.Client{}
+
+	require.Eventually(t, func() bool {
+		resp, err := client.Get(addr)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/collector/client"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LoggingMode selects which logging backend(s) the expvar extension
+// emits request and lifecycle records to.
+type LoggingMode string
+
+const (
+	// LoggingModeZap emits only the existing zap records.
+	LoggingModeZap LoggingMode = "zap"
+	// LoggingModeSlog emits only structured slog records.
+	LoggingModeSlog LoggingMode = "slog"
+	// LoggingModeDual emits both.
+	LoggingModeDual LoggingMode = "dual"
+)
+
+// LoggingConfig configures how the expvar extension logs served
+// requests and lifecycle events.
+type LoggingConfig struct {
+	// Mode is one of "zap", "slog", or "dual". Defaults to "zap".
+	Mode LoggingMode `mapstructure:"mode"`
+}
+
+func (cfg LoggingConfig) mode() LoggingMode {
+	if cfg.Mode == "" {
+		return LoggingModeZap
+	}
+	return cfg.Mode
+}
+
+// requestLogger records every request handled by the expvar HTTP server,
+// plus its start/shutdown lifecycle, to zap and/or slog depending on the
+// configured LoggingMode.
+type requestLogger struct {
+	mode LoggingMode
+	zap  *zap.Logger
+	slog *slog.Logger
+}
+
+func newRequestLogger(cfg LoggingConfig, zapLogger *zap.Logger) *requestLogger {
+	l := &requestLogger{mode: cfg.mode(), zap: zapLogger}
+	switch l.mode {
+	case LoggingModeDual:
+		// Dual mode exists so operators get two independent sinks; routing
+		// slog through an adapter back onto the same zap core it already
+		// writes to would just log every event twice to one place.
+		l.slog = slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	default:
+		l.slog = slog.New(newZapSlogHandler(zapLogger))
+	}
+	return l
+}
+
+// middleware wraps next so that every request is logged with its
+// endpoint, remote address, path, response status, duration, and the
+// authenticated subject (when an auth extension populated one).
+func (l *requestLogger) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+		l.logRequest(r, rec.status, time.Since(start))
+	})
+}
+
+func (l *requestLogger) logRequest(r *http.Request, status int, duration time.Duration) {
+	subject := authSubject(r)
+
+	if l.mode == LoggingModeZap || l.mode == LoggingModeDual {
+		l.zap.Info("expvar request",
+			zap.String("endpoint", r.Host),
+			zap.String("remote_addr", r.RemoteAddr),
+			zap.String("path", r.URL.Path),
+			zap.Int("status", status),
+			zap.Int64("duration_ms", duration.Milliseconds()),
+			zap.String("auth_subject", subject),
+		)
+	}
+	if l.mode == LoggingModeSlog || l.mode == LoggingModeDual {
+		l.slog.Info("expvar request",
+			"endpoint", r.Host,
+			"remote_addr", r.RemoteAddr,
+			"path", r.URL.Path,
+			"status", status,
+			"duration_ms", duration.Milliseconds(),
+			"auth_subject", subject,
+		)
+	}
+}
+
+// logLifecycle records a start/shutdown/shutdown_timeout event. fields
+// must be an even-length list of alternating string keys and values.
+func (l *requestLogger) logLifecycle(event string, fields ...any) {
+	if l.mode == LoggingModeZap || l.mode == LoggingModeDual {
+		zapFields := make([]zap.Field, 0, len(fields)/2)
+		for i := 0; i+1 < len(fields); i += 2 {
+			key, _ := fields[i].(string)
+			zapFields = append(zapFields, zap.Any(key, fields[i+1]))
+		}
+		l.zap.Info(event, zapFields...)
+	}
+	if l.mode == LoggingModeSlog || l.mode == LoggingModeDual {
+		l.slog.Info(event, fields...)
+	}
+}
+
+// authSubject returns the authenticated subject attached to the request
+// context by a configauth authenticator, or "" if the request is
+// unauthenticated.
+func authSubject(r *http.Request) string {
+	info := client.FromContext(r.Context())
+	if info.Auth == nil {
+		return ""
+	}
+	if subject, ok := info.Auth.GetAttribute("subject").(string); ok {
+		return subject
+	}
+	return ""
+}
+
+// statusRecorder captures the status code written by a downstream
+// handler so it can be logged after the response is complete.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// zapSlogHandler adapts an slog.Handler onto a zap.Logger so that
+// slog-based structured records ultimately go through the collector's
+// configured TelemetrySettings.Logger sinks.
+type zapSlogHandler struct {
+	logger *zap.Logger
+	attrs  []slog.Attr
+}
+
+func newZapSlogHandler(logger *zap.Logger) *zapSlogHandler {
+	return &zapSlogHandler{logger: logger}
+}
+
+func (h *zapSlogHandler) Enabled(context.Context, slog.Level) bool {
+	return true
+}
+
+func (h *zapSlogHandler) Handle(_ context.Context, record slog.Record) error {
+	fields := make([]zap.Field, 0, record.NumAttrs()+len(h.attrs))
+	for _, a := range h.attrs {
+		fields = append(fields, zap.Any(a.Key, a.Value.Any()))
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		fields = append(fields, zap.Any(a.Key, a.Value.Any()))
+		return true
+	})
+	if ce := h.logger.Check(slogLevelToZap(record.Level), record.Message); ce != nil {
+		ce.Write(fields...)
+	}
+	return nil
+}
+
+func (h *zapSlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	cloned := &zapSlogHandler{logger: h.logger, attrs: append(append([]slog.Attr{}, h.attrs...), attrs...)}
+	return cloned
+}
+
+func (h *zapSlogHandler) WithGroup(string) slog.Handler {
+	// Grouping is not meaningful for the flat zap fields this adapter
+	// produces, so groups are flattened away.
+	return h
+}
+
+func slogLevelToZap(level slog.Level) zapcore.Level {
+	switch {
+	case level >= slog.LevelError:
+		return zapcore.ErrorLevel
+	case level >= slog.LevelWarn:
+		return zapcore.WarnLevel
+	case level >= slog.LevelInfo:
+		return zapcore.InfoLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestExpvarExtension_StructuredRequestLogging(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	config := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: "0.0.0.0:27786",
+		},
+		Logging: LoggingConfig{Mode: LoggingModeDual},
+	}
+	s := newExtension(config, component.TelemetrySettings{
+		Logger: zap.New(core),
+	})
+	require.NoError(t, s.Start(context.Background(), storagetest.NewStorageHost()))
+	defer s.Shutdown(context.Background())
+
+	require.Contains(t, fieldKeys(logs, "start"), "endpoint")
+
+	addr := fmt.Sprintf("http://0.0.0.0:%d/", 27786)
+	client := &http.Client{}
+	require.Eventually(t, func() bool {
+		resp, err := client.Get(addr)
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 100*time.Millisecond)
+
+	keys := fieldKeys(logs, "expvar request")
+	for _, want := range []string{"endpoint", "remote_addr", "path", "status", "duration_ms", "auth_subject"} {
+		require.Contains(t, keys, want)
+	}
+}
+
+func TestExpvarExtension_ShutdownLoggedOnce(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	config := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: "0.0.0.0:27787",
+		},
+	}
+	s := newExtension(config, component.TelemetrySettings{
+		Logger: zap.New(core),
+	})
+	require.NoError(t, s.Start(context.Background(), storagetest.NewStorageHost()))
+	require.NoError(t, s.Shutdown(context.Background()))
+
+	require.Equal(t, 1, logs.FilterMessage("shutdown").Len())
+}
+
+func fieldKeys(logs *observer.ObservedLogs, message string) []string {
+	var keys []string
+	for _, entry := range logs.FilterMessage(message).All() {
+		for _, f := range entry.Context {
+			keys = append(keys, f.Key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var invalidMetricChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+func sanitizeMetricName(name string) string {
+	return invalidMetricChars.ReplaceAllString(name, "_")
+}
+
+// promSample is a single Prometheus exposition-format sample derived
+// from an expvar value.
+type promSample struct {
+	name   string
+	labels map[string]string
+	value  float64
+}
+
+func (s *stateExtension) handlePrometheus(w http.ResponseWriter, r *http.Request) {
+	samples := collectPrometheusSamples(s.config.Prometheus)
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	emitted := make(map[string]bool, len(samples))
+	for _, sample := range samples {
+		if !emitted[sample.name] {
+			fmt.Fprintf(w, "# HELP %s expvar counter %s\n", sample.name, sample.name)
+			fmt.Fprintf(w, "# TYPE %s counter\n", sample.name)
+			emitted[sample.name] = true
+		}
+		fmt.Fprintf(w, "%s%s %s\n", sample.name, formatLabels(sample.labels), strconv.FormatFloat(sample.value, 'g', -1, 64))
+	}
+}
+
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// collectPrometheusSamples walks the global expvar registry and converts
+// every numeric value into one or more Prometheus samples according to
+// cfg's naming policy.
+func collectPrometheusSamples(cfg PrometheusConfig) []promSample {
+	var samples []promSample
+	expvar.Do(func(kv expvar.KeyValue) {
+		samples = append(samples, collectVar(cfg, sanitizeMetricName(kv.Key), kv.Value, nil, 0)...)
+	})
+	return samples
+}
+
+// collectVar recursively converts a single expvar.Var into samples.
+// Nested expvar.Maps are mapped to labels up to len(cfg.LabelKeys)
+// levels deep; anything nested further is folded into the metric name.
+func collectVar(cfg PrometheusConfig, name string, v expvar.Var, labels map[string]string, depth int) []promSample {
+	switch val := v.(type) {
+	case *expvar.Int:
+		return []promSample{newSample(cfg, name, labels, float64(val.Value()))}
+	case *expvar.Float:
+		return []promSample{newSample(cfg, name, labels, val.Value())}
+	case *expvar.Map:
+		var out []promSample
+		val.Do(func(kv expvar.KeyValue) {
+			if depth < len(cfg.LabelKeys) {
+				out = append(out, collectVar(cfg, name, kv.Value, mergeLabel(labels, cfg.LabelKeys[depth], kv.Key), depth+1)...)
+			} else {
+				out = append(out, collectVar(cfg, name+"_"+sanitizeMetricName(kv.Key), kv.Value, labels, depth+1)...)
+			}
+		})
+		return out
+	default:
+		// Unsupported types (arbitrary expvar.Var/Func) rarely carry a
+		// numeric series Prometheus can scrape, so they're skipped.
+		return nil
+	}
+}
+
+func mergeLabel(labels map[string]string, key, value string) map[string]string {
+	merged := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		merged[k] = v
+	}
+	merged[key] = value
+	return merged
+}
+
+func newSample(cfg PrometheusConfig, name string, labels map[string]string, value float64) promSample {
+	return promSample{name: sanitizeMetricName(cfg.Prefix + name), labels: labels, value: value}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap/zaptest"
+)
+
+// testVarSeq makes expvar names handed out by uniqueVarName unique within
+// the test binary, since expvar.NewInt/NewMap panic if a name is published
+// twice in the same process (e.g. under `go test -count=2`).
+var testVarSeq atomic.Uint64
+
+func uniqueVarName(base string) string {
+	return fmt.Sprintf("%s_%d", base, testVarSeq.Add(1))
+}
+
+func TestCollectPrometheusSamples(t *testing.T) {
+	requestsName := uniqueVarName("requests_total")
+	spansName := uniqueVarName("spans_received_total")
+
+	counter := expvar.NewInt(requestsName)
+	counter.Set(5)
+
+	byTransport := expvar.NewMap(spansName)
+	byTransport.Add("grpc", 3)
+	byTransport.Add("http", 1)
+
+	cfg := PrometheusConfig{
+		Prefix:    "jaeger_",
+		LabelKeys: []string{"transport"},
+	}
+	samples := collectPrometheusSamples(cfg)
+
+	var sawCounter, sawGRPC, sawHTTP bool
+	for _, s := range samples {
+		switch {
+		case s.name == "jaeger_"+requestsName:
+			sawCounter = true
+			require.Equal(t, float64(5), s.value)
+		case s.name == "jaeger_"+spansName && s.labels["transport"] == "grpc":
+			sawGRPC = true
+			require.Equal(t, float64(3), s.value)
+		case s.name == "jaeger_"+spansName && s.labels["transport"] == "http":
+			sawHTTP = true
+			require.Equal(t, float64(1), s.value)
+		}
+	}
+	require.True(t, sawCounter)
+	require.True(t, sawGRPC)
+	require.True(t, sawHTTP)
+}
+
+func TestExpvarExtension_MetricsEndpointScrapedByPrometheusClient(t *testing.T) {
+	probeName := uniqueVarName("scrape_probe")
+	expvar.NewInt(probeName).Set(42)
+
+	config := &Config{
+		ServerConfig: confighttp.ServerConfig{
+			Endpoint: "0.0.0.0:27783",
+		},
+		Prometheus: PrometheusConfig{
+			Enabled: true,
+			Prefix:  "jaeger_",
+		},
+	}
+	s := newExtension(config, component.TelemetrySettings{
+		Logger: zaptest.NewLogger(t),
+	})
+	require.NoError(t, s.Start(context.Background(), storagetest.NewStorageHost()))
+	defer s.Shutdown(context.Background())
+
+	addr := fmt.Sprintf("http://0.0.0.0:%d/metrics", 27783)
+	client := &http.Client{}
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		r, err := client.Get(addr)
+		if err != nil {
+			return false
+		}
+		resp = r
+		return true
+	}, 5*time.Second, 100*time.Millisecond)
+	defer resp.Body.Close()
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	require.NoError(t, err)
+	metricName := "jaeger_" + probeName
+	require.Contains(t, families, metricName)
+	require.Equal(t, float64(42), families[metricName].GetMetric()[0].GetCounter().GetValue())
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+)
+
+// statusPriority orders statuses from least to most severe, so a parent
+// node in the status tree can roll up the worst status among its
+// children. Higher values win.
+var statusPriority = map[componentstatus.Status]int{
+	componentstatus.StatusStopped:          0,
+	componentstatus.StatusStopping:         1,
+	componentstatus.StatusOK:               2,
+	componentstatus.StatusStarting:         3,
+	componentstatus.StatusRecoverableError: 4,
+	componentstatus.StatusPermanentError:   5,
+	componentstatus.StatusFatalError:       6,
+}
+
+// componentStatus is the JSON representation of a single component's
+// health.
+type componentStatus struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// healthReport is the JSON representation served by the /health endpoint.
+type healthReport struct {
+	Status     string                                 `json:"status"`
+	Timestamp  time.Time                              `json:"timestamp"`
+	Error      string                                 `json:"error,omitempty"`
+	Components map[string]map[string]componentStatus `json:"components,omitempty"`
+}
+
+// componentEntry tracks the latest status reported for a single
+// component, along with enough state to debounce recoverable errors.
+type componentEntry struct {
+	mu               sync.RWMutex
+	status           componentstatus.Status
+	err              error
+	timestamp        time.Time
+	recoverableSince time.Time
+}
+
+func (e *componentEntry) update(event *componentstatus.Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	prev := e.status
+	e.status = event.Status()
+	e.err = event.Err()
+	e.timestamp = event.Timestamp()
+
+	if e.status != componentstatus.StatusRecoverableError {
+		e.recoverableSince = time.Time{}
+	} else if prev != componentstatus.StatusRecoverableError {
+		e.recoverableSince = e.timestamp
+	}
+}
+
+// rollupStatus returns the status this entry contributes to an
+// aggregate. A RecoverableError is treated as OK until it has persisted
+// for at least recoveryDuration, at which point it is promoted to
+// PermanentError so the endpoint reports unhealthy.
+func (e *componentEntry) rollupStatus(recoveryDuration time.Duration, now time.Time) componentstatus.Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.status == componentstatus.StatusRecoverableError && !e.recoverableSince.IsZero() {
+		if now.Sub(e.recoverableSince) < recoveryDuration {
+			return componentstatus.StatusOK
+		}
+		return componentstatus.StatusPermanentError
+	}
+	return e.status
+}
+
+func (e *componentEntry) toJSON() componentStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	cs := componentStatus{
+		Status:    e.status.String(),
+		Timestamp: e.timestamp,
+	}
+	if e.err != nil {
+		cs.Error = e.err.Error()
+	}
+	return cs
+}
+
+// statusAggregator receives the componentstatus.Event notifications that
+// the extension's ComponentStatusChanged forwards to it and keeps a tree of the latest status for every component, keyed by
+// component kind -> pipeline -> component ID.
+type statusAggregator struct {
+	mu               sync.RWMutex
+	recoveryDuration time.Duration
+	// tree[kind][pipeline][componentID] -> latest status
+	tree map[component.Kind]map[string]map[component.ID]*componentEntry
+}
+
+func newStatusAggregator(recoveryDuration time.Duration) *statusAggregator {
+	return &statusAggregator{
+		recoveryDuration: recoveryDuration,
+		tree:             make(map[component.Kind]map[string]map[component.ID]*componentEntry),
+	}
+}
+
+// pipelineScopeKey is used for components not associated with a pipeline
+// (e.g. extensions and connectors report against this key).
+const pipelineScopeKey = "-"
+
+// RecordEvent updates the tree with a status event reported by source.
+func (a *statusAggregator) RecordEvent(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	pipelineIDs := source.PipelineIDs()
+	if len(pipelineIDs) == 0 {
+		a.entry(source.Kind(), pipelineScopeKey, source.ComponentID()).update(event)
+		return
+	}
+	for pID := range pipelineIDs {
+		a.entry(source.Kind(), pID.String(), source.ComponentID()).update(event)
+	}
+}
+
+// entry returns (creating if necessary) the tree node for the given
+// coordinates. Callers must hold a.mu.
+func (a *statusAggregator) entry(kind component.Kind, pipelineKey string, id component.ID) *componentEntry {
+	byPipeline, ok := a.tree[kind]
+	if !ok {
+		byPipeline = make(map[string]map[component.ID]*componentEntry)
+		a.tree[kind] = byPipeline
+	}
+	byComponent, ok := byPipeline[pipelineKey]
+	if !ok {
+		byComponent = make(map[component.ID]*componentEntry)
+		byPipeline[pipelineKey] = byComponent
+	}
+	entry, ok := byComponent[id]
+	if !ok {
+		entry = &componentEntry{}
+		byComponent[id] = entry
+	}
+	return entry
+}
+
+// Report builds a health report for the given pipeline. An empty
+// pipeline reports across all pipelines. When verbose is true, the full
+// per-component tree is included. It also returns the HTTP status code
+// that should be returned for the report: 404 when a pipeline is named
+// and no component has reported against it, otherwise 200 when the
+// aggregate status is OK or RecoverableError and 503 for anything else.
+func (a *statusAggregator) Report(pipeline string, verbose bool) (*healthReport, int) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	now := time.Now()
+	worst := componentstatus.StatusOK
+	var worstEntry *componentEntry
+	var details map[string]map[string]componentStatus
+	if verbose {
+		details = make(map[string]map[string]componentStatus)
+	}
+	found := false
+
+	for kind, byPipeline := range a.tree {
+		for pKey, byComponent := range byPipeline {
+			if pipeline != "" && pKey != pipeline {
+				continue
+			}
+			for id, entry := range byComponent {
+				rolled := entry.rollupStatus(a.recoveryDuration, now)
+				// The first entry seen always replaces the StatusOK
+				// baseline, regardless of its priority, so statuses that
+				// rank below OK (Stopped, Stopping) aren't silently
+				// dropped by the ">" comparison below.
+				if !found || statusPriority[rolled] > statusPriority[worst] {
+					worst = rolled
+					worstEntry = entry
+				}
+				found = true
+				if verbose {
+					groupKey := kind.String() + "/" + pKey
+					if details[groupKey] == nil {
+						details[groupKey] = make(map[string]componentStatus)
+					}
+					details[groupKey][id.String()] = entry.toJSON()
+				}
+			}
+		}
+	}
+
+	report := &healthReport{Status: worst.String(), Timestamp: now}
+	if worstEntry != nil {
+		snapshot := worstEntry.toJSON()
+		report.Error = snapshot.Error
+	}
+	if verbose {
+		report.Components = details
+	}
+
+	code := http.StatusOK
+	if worst != componentstatus.StatusOK && worst != componentstatus.StatusRecoverableError {
+		code = http.StatusServiceUnavailable
+	}
+	if pipeline != "" && !found {
+		code = http.StatusNotFound
+	}
+	return report, code
+}
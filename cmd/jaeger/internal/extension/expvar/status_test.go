@@ -0,0 +1,86 @@
+// Copyright (c) 2024 The Jaeger Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package expvar
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+func newTestInstanceID(t *testing.T, kind component.Kind, pipelineIDs ...pipeline.ID) *componentstatus.InstanceID {
+	t.Helper()
+	id := componentstatus.NewInstanceID(component.MustNewID("test"), kind, pipelineIDs...)
+	return id
+}
+
+func TestStatusAggregator_StartStopTransitions(t *testing.T) {
+	agg := newStatusAggregator(time.Minute)
+	source := newTestInstanceID(t, component.KindReceiver, pipeline.NewID(pipeline.SignalTraces))
+
+	agg.RecordEvent(source, componentstatus.NewEvent(componentstatus.StatusStarting))
+	report, code := agg.Report("", false)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, componentstatus.StatusStarting.String(), report.Status)
+
+	agg.RecordEvent(source, componentstatus.NewEvent(componentstatus.StatusOK))
+	report, code = agg.Report("", false)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, componentstatus.StatusOK.String(), report.Status)
+
+	agg.RecordEvent(source, componentstatus.NewEvent(componentstatus.StatusStopped))
+	report, code = agg.Report("", false)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, componentstatus.StatusStopped.String(), report.Status)
+}
+
+func TestStatusAggregator_RecoverableErrorDebounce(t *testing.T) {
+	agg := newStatusAggregator(50 * time.Millisecond)
+	source := newTestInstanceID(t, component.KindExporter, pipeline.NewID(pipeline.SignalTraces))
+
+	agg.RecordEvent(source, componentstatus.NewRecoverableErrorEvent(assert.AnError))
+	_, code := agg.Report("", false)
+	require.Equal(t, http.StatusOK, code, "should stay healthy before the debounce window elapses")
+
+	require.Eventually(t, func() bool {
+		_, code := agg.Report("", false)
+		return code == http.StatusServiceUnavailable
+	}, time.Second, 5*time.Millisecond, "should flip unhealthy once the error persists past the debounce window")
+}
+
+func TestStatusAggregator_PipelineScopedQuery(t *testing.T) {
+	agg := newStatusAggregator(time.Minute)
+	tracesSource := newTestInstanceID(t, component.KindReceiver, pipeline.NewID(pipeline.SignalTraces))
+	metricsSource := newTestInstanceID(t, component.KindReceiver, pipeline.NewID(pipeline.SignalMetrics))
+
+	agg.RecordEvent(tracesSource, componentstatus.NewEvent(componentstatus.StatusOK))
+	agg.RecordEvent(metricsSource, componentstatus.NewPermanentErrorEvent(assert.AnError))
+
+	_, code := agg.Report(pipeline.NewID(pipeline.SignalTraces).String(), false)
+	assert.Equal(t, http.StatusOK, code)
+
+	_, code = agg.Report(pipeline.NewID(pipeline.SignalMetrics).String(), false)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	_, code = agg.Report("does-not-exist", false)
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestStatusAggregator_VerboseReport(t *testing.T) {
+	agg := newStatusAggregator(time.Minute)
+	source := newTestInstanceID(t, component.KindReceiver, pipeline.NewID(pipeline.SignalTraces))
+	agg.RecordEvent(source, componentstatus.NewEvent(componentstatus.StatusOK))
+
+	report, _ := agg.Report("", true)
+	require.Len(t, report.Components, 1)
+	for _, byID := range report.Components {
+		assert.Len(t, byID, 1)
+	}
+}